@@ -0,0 +1,133 @@
+package logger
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// FieldKeys задаёт имена полей, которые логгер пишет в структурированный
+// вывод. Нулевые значения заменяются значениями по умолчанию в
+// withDefaults, так что большинству инсталляций достаточно переопределить
+// только нужные ключи (например, под соглашения ECS/Elastic).
+type FieldKeys struct {
+	Message   string // по умолчанию "msg"
+	Level     string // по умолчанию "level"
+	Timestamp string // по умолчанию "ts"
+	Caller    string // по умолчанию "caller"
+
+	TraceID   string // по умолчанию "traceID"
+	SpanID    string // по умолчанию "spanID"
+	ServiceID string // по умолчанию "service.id"
+}
+
+func (fk FieldKeys) withDefaults() FieldKeys {
+	if fk.Message == "" {
+		fk.Message = "msg"
+	}
+	if fk.Level == "" {
+		fk.Level = "level"
+	}
+	if fk.Timestamp == "" {
+		fk.Timestamp = "ts"
+	}
+	if fk.Caller == "" {
+		fk.Caller = "caller"
+	}
+	if fk.TraceID == "" {
+		fk.TraceID = "traceID"
+	}
+	if fk.SpanID == "" {
+		fk.SpanID = "spanID"
+	}
+	if fk.ServiceID == "" {
+		fk.ServiceID = "service.id"
+	}
+	return fk
+}
+
+// ContextExtractor извлекает дополнительные поля из context.Context. Задаётся
+// через LogConfig.ContextExtractors для полей, которые не покрываются
+// встроенным извлечением trace/span/service ID (например, request ID или
+// tenant ID, специфичные для конкретного сервиса).
+type ContextExtractor func(ctx context.Context) []zap.Field
+
+type serviceIDKey struct{}
+
+// ContextWithServiceID возвращает context.Context, из которого WithContext /
+// LoggerFromContext смогут извлечь service ID.
+func ContextWithServiceID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, serviceIDKey{}, id)
+}
+
+// contextFields извлекает trace ID, span ID и service ID из ctx, а также
+// прогоняет сконфигурированные ContextExtractor'ы, и возвращает итоговый
+// набор полей под именами из l.fieldKeys.
+func (l *Logger) contextFields(ctx context.Context) []zap.Field {
+	var fields []zap.Field
+
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		fields = append(fields,
+			zap.String(l.fieldKeys.TraceID, sc.TraceID().String()),
+			zap.String(l.fieldKeys.SpanID, sc.SpanID().String()),
+		)
+	}
+
+	if id, ok := ctx.Value(serviceIDKey{}).(string); ok && id != "" {
+		fields = append(fields, zap.String(l.fieldKeys.ServiceID, id))
+	}
+
+	for _, extractor := range l.extractors {
+		fields = append(fields, extractor(ctx)...)
+	}
+
+	return fields
+}
+
+// WithContext возвращает логгер, дополненный полями trace ID, span ID,
+// service ID и результатами ContextExtractor'ов, извлечёнными из ctx.
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	fields := l.contextFields(ctx)
+	if len(fields) == 0 {
+		return l
+	}
+	return l.WithFields(fields...)
+}
+
+var (
+	defaultLoggerMu sync.Mutex
+	defaultLogger   *Logger
+)
+
+// SetDefault устанавливает логгер, используемый LoggerFromContext.
+func SetDefault(l *Logger) {
+	defaultLoggerMu.Lock()
+	defer defaultLoggerMu.Unlock()
+	defaultLogger = l
+}
+
+// Default возвращает логгер, используемый LoggerFromContext, создавая
+// логгер с конфигурацией по умолчанию, если SetDefault ещё не вызывался.
+func Default() *Logger {
+	defaultLoggerMu.Lock()
+	defer defaultLoggerMu.Unlock()
+	if defaultLogger == nil {
+		l, err := New()
+		if err != nil {
+			// New() с конфигурацией по умолчанию не должна падать; паникуем,
+			// чтобы не возвращать nil из функции, на которую рассчитывают
+			// вызывающие без явной проверки ошибки.
+			panic(err)
+		}
+		defaultLogger = l
+	}
+	return defaultLogger
+}
+
+// LoggerFromContext возвращает логгер по умолчанию (см. SetDefault),
+// дополненный полями, извлечёнными из ctx — см. WithContext.
+func LoggerFromContext(ctx context.Context) *Logger {
+	return Default().WithContext(ctx)
+}
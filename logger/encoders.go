@@ -0,0 +1,170 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+var logfmtBufferPool = buffer.NewPool()
+
+// logfmtEncoder реализует zapcore.Encoder в формате key=value, удобном для
+// grep/awk. Поля накапливаются через встроенный zapcore.MapObjectEncoder,
+// а итоговая строка собирается в EncodeEntry в порядке: стандартные ключи
+// из EncoderConfig, затем произвольные поля в алфавитном порядке (для
+// детерминированного вывода), и последним — стектрейс, если он есть.
+type logfmtEncoder struct {
+	*zapcore.MapObjectEncoder
+	cfg zapcore.EncoderConfig
+}
+
+func newLogfmtEncoder(cfg zapcore.EncoderConfig) zapcore.Encoder {
+	return &logfmtEncoder{MapObjectEncoder: zapcore.NewMapObjectEncoder(), cfg: cfg}
+}
+
+func (enc *logfmtEncoder) Clone() zapcore.Encoder {
+	clone := zapcore.NewMapObjectEncoder()
+	for k, v := range enc.MapObjectEncoder.Fields {
+		clone.Fields[k] = v
+	}
+	return &logfmtEncoder{MapObjectEncoder: clone, cfg: enc.cfg}
+}
+
+func (enc *logfmtEncoder) EncodeEntry(entry zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	line := enc.Clone().(*logfmtEncoder)
+	for _, f := range fields {
+		f.AddTo(line)
+	}
+
+	buf := logfmtBufferPool.Get()
+	first := true
+	writePair := func(key string, val interface{}) {
+		if !first {
+			buf.AppendByte(' ')
+		}
+		first = false
+		buf.AppendString(key)
+		buf.AppendByte('=')
+		buf.AppendString(logfmtQuote(fmt.Sprintf("%v", val)))
+	}
+
+	if enc.cfg.TimeKey != "" {
+		writePair(enc.cfg.TimeKey, entry.Time.Format(time.RFC3339))
+	}
+	if enc.cfg.LevelKey != "" {
+		writePair(enc.cfg.LevelKey, entry.Level.String())
+	}
+	if enc.cfg.NameKey != "" && entry.LoggerName != "" {
+		writePair(enc.cfg.NameKey, entry.LoggerName)
+	}
+	if enc.cfg.CallerKey != "" && entry.Caller.Defined {
+		writePair(enc.cfg.CallerKey, entry.Caller.String())
+	}
+	if enc.cfg.MessageKey != "" {
+		writePair(enc.cfg.MessageKey, entry.Message)
+	}
+
+	keys := make([]string, 0, len(line.Fields))
+	for k := range line.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		writePair(k, line.Fields[k])
+	}
+
+	if entry.Stack != "" && enc.cfg.StacktraceKey != "" {
+		writePair(enc.cfg.StacktraceKey, entry.Stack)
+	}
+
+	buf.AppendString(enc.cfg.LineEnding)
+	return buf, nil
+}
+
+// logfmtQuote оборачивает значение в кавычки, если оно содержит пробел,
+// знак равенства, кавычку или пусто — иначе возвращает как есть.
+func logfmtQuote(s string) string {
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// newECSEncoder возвращает JSON-энкодер с именами полей по стандарту Elastic
+// Common Schema (@timestamp, message, log.level, log.logger, log.caller,
+// error.stack_trace), независимо от LogConfig.FieldKeys — "ecs" задаёт
+// фиксированную, совместимую с ELK/Kibana схему имён.
+func newECSEncoder(cfg zapcore.EncoderConfig) zapcore.Encoder {
+	cfg.TimeKey = "@timestamp"
+	cfg.LevelKey = "log.level"
+	cfg.MessageKey = "message"
+	cfg.NameKey = "log.logger"
+	cfg.CallerKey = "log.caller"
+	cfg.StacktraceKey = "error.stack_trace"
+	cfg.EncodeLevel = zapcore.LowercaseLevelEncoder
+	cfg.EncodeTime = zapcore.ISO8601TimeEncoder
+	return zapcore.NewJSONEncoder(cfg)
+}
+
+var ansiLevelColors = map[zapcore.Level]string{
+	zapcore.DebugLevel:  "\x1b[90m", // серый
+	zapcore.InfoLevel:   "\x1b[32m", // зелёный
+	zapcore.WarnLevel:   "\x1b[33m", // жёлтый
+	zapcore.ErrorLevel:  "\x1b[31m", // красный
+	zapcore.DPanicLevel: "\x1b[35m", // пурпурный
+	zapcore.PanicLevel:  "\x1b[35m",
+	zapcore.FatalLevel:  "\x1b[41m", // красный фон
+}
+
+const ansiReset = "\x1b[0m"
+
+// colorLevelEncoder раскрашивает уровень ANSI-последовательностями —
+// newConsoleColorEncoder ставит его в EncodeLevel для "console-color".
+func colorLevelEncoder(level zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	color, ok := ansiLevelColors[level]
+	if !ok {
+		enc.AppendString(level.CapitalString())
+		return
+	}
+	enc.AppendString(color + level.CapitalString() + ansiReset)
+}
+
+// isTerminalSink сообщает, подключён ли конкретный sink (по его Path) к TTY —
+// цвет включается только в этом случае, чтобы не замусорить логи
+// escape-последовательностями при перенаправлении вывода в файл или
+// агрегатор. Для файловых sink'ов (в т.ч. ротируемых) всегда возвращает
+// false — файл никогда не является терминалом, независимо от того, TTY ли
+// stdout/stderr самого процесса.
+func isTerminalSink(path string) bool {
+	var f *os.File
+	switch path {
+	case "", "stdout":
+		f = os.Stdout
+	case "stderr":
+		f = os.Stderr
+	default:
+		return false
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// newConsoleColorEncoder возвращает консольный энкодер, раскрашивающий
+// уровень логирования, если sink с данным Path — TTY; иначе ведёт себя как
+// обычный "console".
+func newConsoleColorEncoder(cfg zapcore.EncoderConfig, path string) zapcore.Encoder {
+	if isTerminalSink(path) {
+		cfg.EncodeLevel = colorLevelEncoder
+	}
+	return zapcore.NewConsoleEncoder(cfg)
+}
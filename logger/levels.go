@@ -0,0 +1,83 @@
+package logger
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// levelGatedCore — zapcore.Core, дополнительно сверяющий level с level
+// (обычно zap.AtomicLevel), поверх того, что уже разрешает оборачиваемый
+// core. Используется NewLogger, чтобы включить SetLevel/LevelHandler для
+// логгера, чей core был собран снаружи и не умеет сам эти вызовы
+// обслуживать: сузить допустимый уровень так можно, а расширить его за
+// пределы минимума, заданного в исходном core, — нет.
+type levelGatedCore struct {
+	zapcore.Core
+	level zapcore.LevelEnabler
+}
+
+func (c *levelGatedCore) Enabled(lvl zapcore.Level) bool {
+	return c.level.Enabled(lvl) && c.Core.Enabled(lvl)
+}
+
+func (c *levelGatedCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelGatedCore{Core: c.Core.With(fields), level: c.level}
+}
+
+func (c *levelGatedCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
+
+func (c *levelGatedCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(ent, fields)
+}
+
+// SetLevel меняет уровень логирования основного sink'а на лету, без
+// пересоздания логгера. Уровень дополнительных OutputSinks не затрагивается —
+// он остаётся таким, каким был задан явно при конструировании.
+func (l *Logger) SetLevel(level string) error {
+	var lvl zapcore.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return fmt.Errorf("invalid log level: %v", err)
+	}
+	l.level.SetLevel(lvl)
+	return nil
+}
+
+// LevelHandler возвращает http.Handler для просмотра и изменения уровня
+// логирования основного sink'а в рантайме: GET отдаёт текущий уровень как
+// JSON, PUT принимает {"level":"debug"}. Реализация — zap.AtomicLevel,
+// который уже умеет обслуживать оба метода.
+func (l *Logger) LevelHandler() http.Handler {
+	return l.level
+}
+
+// InstallSignalHandler подписывается на sig и при каждом получении сигнала
+// переключает уровень логирования между сконфигурированным значением и
+// debug — удобно для включения подробных логов у уже запущенного процесса
+// без перезапуска (типичный приём для дежурных при разборе инцидентов).
+func (l *Logger) InstallSignalHandler(sig os.Signal) {
+	configuredLevel := l.level.Level()
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sig)
+
+	go func() {
+		debug := false
+		for range ch {
+			if debug {
+				l.level.SetLevel(configuredLevel)
+			} else {
+				l.level.SetLevel(zapcore.DebugLevel)
+			}
+			debug = !debug
+		}
+	}()
+}
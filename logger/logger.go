@@ -3,6 +3,7 @@ package logger
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -11,13 +12,53 @@ import (
 // Logger - обертка вокруг zap.Logger
 type Logger struct {
 	*zap.Logger
+
+	fieldKeys  FieldKeys
+	extractors []ContextExtractor
+	level      zap.AtomicLevel
 }
 
 // LogConfig конфигурация для логгера
 type LogConfig struct {
 	Level      string // debug, info, warn, error, fatal
 	OutputPath string // путь к файлу или "stdout" для вывода в консоль
-	Format     string // json или console
+	Format     string // json, console, logfmt, ecs или console-color
+
+	// Ротация основного OutputPath по размеру (lumberjack). Игнорируется,
+	// если OutputPath == "stdout"/"stderr".
+	MaxSize    int // максимальный размер файла в мегабайтах
+	MaxAge     int // максимальное количество дней хранения старых файлов
+	MaxBackups int // максимальное количество старых файлов
+	Compress   bool
+
+	// Ротация основного OutputPath по времени (file-rotatelogs). Если задано,
+	// имеет приоритет над ротацией по размеру.
+	RotationTime time.Duration
+	LinkName     string // симлинк на текущий файл, например "latest.log"
+
+	// OutputSinks — дополнительные пункты назначения логов (помимо
+	// OutputPath), каждый со своим уровнем, форматом и ротацией. Позволяет,
+	// например, писать JSON в ротируемый файл и одновременно выводить
+	// человекочитаемый лог в stdout.
+	OutputSinks []OutputSink
+
+	// FieldKeys переопределяет имена стандартных и контекстных полей
+	// (например, под соглашения ECS/Elastic). Пустые поля заменяются
+	// значениями по умолчанию.
+	FieldKeys FieldKeys
+
+	// ContextExtractors — дополнительные функции извлечения полей из
+	// context.Context, используемые WithContext/LoggerFromContext помимо
+	// встроенного извлечения trace/span/service ID.
+	ContextExtractors []ContextExtractor
+
+	// Sampling ограничивает объём одинаковых сообщений в секунду (см.
+	// SamplingConfig). Применяется ко всем sink'ам сразу, после Tee.
+	Sampling SamplingConfig
+
+	// RateLimit — альтернативный/дополнительный к Sampling токен-бакет
+	// лимитер по сообщениям в секунду (см. RateLimitConfig).
+	RateLimit RateLimitConfig
 }
 
 // New создает новый экземпляр логгера с конфигурацией по умолчанию
@@ -29,31 +70,23 @@ func New() (*Logger, error) {
 	})
 }
 
-// NewWithConfig создает новый экземпляр логгера с заданной конфигурацией
+// NewWithConfig создает новый экземпляр логгера с заданной конфигурацией.
+// Основной OutputPath/Level/Format всегда формирует первый sink; если заданы
+// OutputSinks, для каждого из них строится свой core (со своим уровнем,
+// форматом и ротацией), и все core объединяются через zapcore.Tee — это
+// позволяет, например, одновременно писать JSON в ротируемый файл и выводить
+// человекочитаемый лог в stdout.
 func NewWithConfig(config LogConfig) (*Logger, error) {
-	// Настройка уровня логирования
-	level := zap.NewAtomicLevel()
-	err := level.UnmarshalText([]byte(config.Level))
-	if err != nil {
-		return nil, fmt.Errorf("invalid log level: %v", err)
-	}
-
-	// Настройка вывода
-	var outputPaths []string
-	if config.OutputPath == "stdout" {
-		outputPaths = []string{"stdout"}
-	} else {
-		outputPaths = []string{config.OutputPath}
-	}
+	fieldKeys := config.FieldKeys.withDefaults()
 
 	// Настройка энкодера
 	encoderConfig := zapcore.EncoderConfig{
-		TimeKey:        "ts",
-		LevelKey:       "level",
+		TimeKey:        fieldKeys.Timestamp,
+		LevelKey:       fieldKeys.Level,
 		NameKey:        "logger",
-		CallerKey:      "caller",
+		CallerKey:      fieldKeys.Caller,
 		FunctionKey:    zapcore.OmitKey,
-		MessageKey:     "msg",
+		MessageKey:     fieldKeys.Message,
 		StacktraceKey:  "stacktrace",
 		LineEnding:     zapcore.DefaultLineEnding,
 		EncodeLevel:    zapcore.CapitalLevelEncoder,
@@ -62,21 +95,63 @@ func NewWithConfig(config LogConfig) (*Logger, error) {
 		EncodeCaller:   zapcore.ShortCallerEncoder,
 	}
 
-	zapConfig := zap.Config{
-		Level:            level,
-		Development:      false,
-		Encoding:         config.Format,
-		EncoderConfig:    encoderConfig,
-		OutputPaths:      outputPaths,
-		ErrorOutputPaths: []string{"stderr"},
+	// Уровень основного sink'а хранится как zap.AtomicLevel, а не фиксированный
+	// zapcore.Level, чтобы его можно было менять на лету через SetLevel,
+	// LevelHandler и InstallSignalHandler без пересоздания логгера.
+	primaryLevel := zap.NewAtomicLevel()
+	if err := primaryLevel.UnmarshalText([]byte(config.Level)); err != nil {
+		return nil, fmt.Errorf("invalid log level: %v", err)
+	}
+
+	primarySink := OutputSink{
+		Path:         config.OutputPath,
+		Format:       config.Format,
+		MaxSize:      config.MaxSize,
+		MaxAge:       config.MaxAge,
+		MaxBackups:   config.MaxBackups,
+		Compress:     config.Compress,
+		RotationTime: config.RotationTime,
+		LinkName:     config.LinkName,
 	}
 
-	zapLogger, err := zapConfig.Build(zap.AddCallerSkip(1))
+	cores := make([]zapcore.Core, 0, 1+len(config.OutputSinks))
+	primaryCore, err := buildCoreWithEnabler(primarySink, encoderConfig, primaryLevel)
 	if err != nil {
 		return nil, err
 	}
+	cores = append(cores, primaryCore)
+
+	for _, sink := range config.OutputSinks {
+		if sink.Level == "" {
+			sink.Level = config.Level
+		}
+		if sink.Format == "" {
+			sink.Format = config.Format
+		}
+		core, err := buildCore(sink, encoderConfig)
+		if err != nil {
+			return nil, err
+		}
+		cores = append(cores, core)
+	}
+
+	combinedCore := zapcore.NewTee(cores...)
+	combinedCore = wrapSampling(combinedCore, config.Sampling)
+	combinedCore = wrapRateLimit(combinedCore, config.RateLimit)
 
-	return &Logger{zapLogger}, nil
+	zapLogger := zap.New(
+		combinedCore,
+		zap.AddCaller(),
+		zap.AddCallerSkip(1),
+		zap.ErrorOutput(zapcore.Lock(zapcore.AddSync(os.Stderr))),
+	)
+
+	return &Logger{
+		Logger:     zapLogger,
+		fieldKeys:  fieldKeys,
+		extractors: config.ContextExtractors,
+		level:      primaryLevel,
+	}, nil
 }
 
 // Debug логирует сообщение с уровнем Debug
@@ -114,7 +189,12 @@ func (l *Logger) Fatalf(format string, err error) {
 
 // WithFields создает новый логгер с дополнительными полями
 func (l *Logger) WithFields(fields ...zap.Field) *Logger {
-	return &Logger{l.Logger.With(fields...)}
+	return &Logger{
+		Logger:     l.Logger.With(fields...),
+		fieldKeys:  l.fieldKeys,
+		extractors: l.extractors,
+		level:      l.level,
+	}
 }
 
 // Вспомогательные функции для создания полей
@@ -150,9 +230,24 @@ func Duration(key string, val float64) zap.Field {
 	return zap.Float64(key, val)
 }
 
-// NewLogger creates a new Logger instance
+// NewLogger creates a new Logger instance from an already-constructed
+// *zap.Logger. Since the passed-in logger's core is opaque, dynamic level
+// control (SetLevel/LevelHandler/InstallSignalHandler) is wired in by
+// wrapping its core with a levelGatedCore bound to a zap.AtomicLevel that
+// starts at zapLogger's own minimum enabled level:
+// this lets SetLevel *narrow* what the zapLogger already allows (e.g.
+// restrict a Debug-level zap.NewDevelopment() logger to Error at runtime),
+// but it cannot *widen* it past whatever minimum level zapLogger's own core
+// was built with.
 func NewLogger(zapLogger *zap.Logger) *Logger {
+	level := zap.NewAtomicLevelAt(zapLogger.Level())
+	gated := zapLogger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &levelGatedCore{Core: core, level: level}
+	}))
+
 	return &Logger{
-		Logger: zapLogger,
+		Logger:    gated,
+		fieldKeys: FieldKeys{}.withDefaults(),
+		level:     level,
 	}
 }
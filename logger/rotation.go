@@ -0,0 +1,125 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	rotatelogs "github.com/lestrrat-go/file-rotatelogs"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// OutputSink описывает один пункт назначения логов: путь, уровень и формат
+// могут отличаться от основной конфигурации, что позволяет, например,
+// одновременно писать JSON в ротируемый файл и human-readable вывод в stdout.
+type OutputSink struct {
+	Path   string // "stdout", "stderr" или путь к файлу
+	Level  string // debug, info, warn, error, fatal; по умолчанию берётся из LogConfig.Level
+	Format string // json, console, ...; по умолчанию берётся из LogConfig.Format
+
+	// Ротация по размеру (lumberjack). Применяется, если задано хотя бы одно
+	// из MaxSize, MaxAge, MaxBackups, Compress, а RotationTime не задан.
+	MaxSize    int // максимальный размер файла в мегабайтах
+	MaxAge     int // максимальное количество дней хранения старых файлов
+	MaxBackups int // максимальное количество старых файлов
+	Compress   bool
+
+	// Ротация по времени (file-rotatelogs). Применяется, если RotationTime > 0.
+	RotationTime time.Duration
+	LinkName     string // путь к симлинку, всегда указывающему на текущий файл (например "latest.log")
+}
+
+// buildWriteSyncer создаёт zapcore.WriteSyncer для указанного sink'а,
+// выбирая ротатор по заполненным полям: RotationTime включает ротацию по
+// времени (file-rotatelogs), MaxSize/MaxAge/MaxBackups/Compress — по размеру
+// (lumberjack). Если ни одно из полей не задано, файл открывается напрямую.
+func buildWriteSyncer(sink OutputSink) (zapcore.WriteSyncer, error) {
+	switch sink.Path {
+	case "", "stdout":
+		return zapcore.AddSync(os.Stdout), nil
+	case "stderr":
+		return zapcore.AddSync(os.Stderr), nil
+	}
+
+	if sink.RotationTime > 0 {
+		opts := []rotatelogs.Option{
+			rotatelogs.WithRotationTime(sink.RotationTime),
+		}
+		if sink.LinkName != "" {
+			opts = append(opts, rotatelogs.WithLinkName(sink.LinkName))
+		}
+		if sink.MaxAge > 0 {
+			opts = append(opts, rotatelogs.WithMaxAge(time.Duration(sink.MaxAge)*24*time.Hour))
+		}
+		rl, err := rotatelogs.New(sink.Path+".%Y%m%d%H%M", opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to init time-based rotator for %q: %v", sink.Path, err)
+		}
+		return zapcore.AddSync(rl), nil
+	}
+
+	if sink.MaxSize > 0 || sink.MaxAge > 0 || sink.MaxBackups > 0 || sink.Compress {
+		lj := &lumberjack.Logger{
+			Filename:   sink.Path,
+			MaxSize:    sink.MaxSize,
+			MaxAge:     sink.MaxAge,
+			MaxBackups: sink.MaxBackups,
+			Compress:   sink.Compress,
+		}
+		return zapcore.AddSync(lj), nil
+	}
+
+	f, err := os.OpenFile(sink.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file %q: %v", sink.Path, err)
+	}
+	return zapcore.AddSync(f), nil
+}
+
+// buildEncoder создаёт zapcore.Encoder для заданного формата. path — это
+// sink.Path, по которому "console-color" определяет, подключён ли
+// реальный пункт назначения sink'а (а не stdout процесса) к TTY.
+func buildEncoder(format string, path string, encoderConfig zapcore.EncoderConfig) (zapcore.Encoder, error) {
+	switch format {
+	case "console":
+		return zapcore.NewConsoleEncoder(encoderConfig), nil
+	case "json":
+		return zapcore.NewJSONEncoder(encoderConfig), nil
+	case "logfmt":
+		return newLogfmtEncoder(encoderConfig), nil
+	case "ecs":
+		return newECSEncoder(encoderConfig), nil
+	case "console-color":
+		return newConsoleColorEncoder(encoderConfig, path), nil
+	default:
+		return nil, fmt.Errorf("unsupported log format: %q", format)
+	}
+}
+
+// buildCore собирает zapcore.Core для одного sink'а: энкодер по формату,
+// WriteSyncer с учётом ротации и уровень логирования из sink.Level.
+func buildCore(sink OutputSink, encoderConfig zapcore.EncoderConfig) (zapcore.Core, error) {
+	var level zapcore.Level
+	if err := level.UnmarshalText([]byte(sink.Level)); err != nil {
+		return nil, fmt.Errorf("invalid log level: %v", err)
+	}
+	return buildCoreWithEnabler(sink, encoderConfig, level)
+}
+
+// buildCoreWithEnabler собирает zapcore.Core для sink'а, используя заданный
+// LevelEnabler вместо sink.Level — применяется для основного sink'а, уровень
+// которого управляется динамически через zap.AtomicLevel (см. SetLevel).
+func buildCoreWithEnabler(sink OutputSink, encoderConfig zapcore.EncoderConfig, enabler zapcore.LevelEnabler) (zapcore.Core, error) {
+	encoder, err := buildEncoder(sink.Format, sink.Path, encoderConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	ws, err := buildWriteSyncer(sink)
+	if err != nil {
+		return nil, err
+	}
+
+	return zapcore.NewCore(encoder, ws, enabler), nil
+}
@@ -0,0 +1,61 @@
+package logger
+
+import "go.uber.org/zap"
+
+// SugaredLogger - обертка вокруг zap.SugaredLogger. В отличие от Logger,
+// принимает printf-style и loosely-typed key/value аргументы вместо
+// zap.Field, что удобнее для разового логирования, но медленнее и без
+// компиляторных проверок типов полей.
+type SugaredLogger struct {
+	*zap.SugaredLogger
+}
+
+// Sugar возвращает SugaredLogger поверх того же core, что и l, сохраняя
+// уровень, вывод и формат. Быстрый типизированный API Logger остаётся
+// основным способом логирования, Sugar — опциональная альтернатива для
+// случаев, где zap.Field неудобен.
+func (l *Logger) Sugar() *SugaredLogger {
+	return &SugaredLogger{l.Logger.Sugar()}
+}
+
+// Debugf логирует форматированное сообщение с уровнем Debug
+func (l *SugaredLogger) Debugf(format string, args ...interface{}) {
+	l.SugaredLogger.Debugf(format, args...)
+}
+
+// Infof логирует форматированное сообщение с уровнем Info
+func (l *SugaredLogger) Infof(format string, args ...interface{}) {
+	l.SugaredLogger.Infof(format, args...)
+}
+
+// Warnf логирует форматированное сообщение с уровнем Warn
+func (l *SugaredLogger) Warnf(format string, args ...interface{}) {
+	l.SugaredLogger.Warnf(format, args...)
+}
+
+// Errorf логирует форматированное сообщение с уровнем Error
+func (l *SugaredLogger) Errorf(format string, args ...interface{}) {
+	l.SugaredLogger.Errorf(format, args...)
+}
+
+// Fatalf логирует форматированное сообщение с уровнем Fatal и завершает
+// программу. В отличие от (*Logger).Fatalf, принимает произвольные
+// варargs и ведёт себя как fmt.Sprintf, а не только (format, err).
+func (l *SugaredLogger) Fatalf(format string, args ...interface{}) {
+	l.SugaredLogger.Fatalf(format, args...)
+}
+
+// Infow логирует сообщение с уровнем Info и loosely-typed key/value парами
+func (l *SugaredLogger) Infow(msg string, keysAndValues ...interface{}) {
+	l.SugaredLogger.Infow(msg, keysAndValues...)
+}
+
+// Warnw логирует сообщение с уровнем Warn и loosely-typed key/value парами
+func (l *SugaredLogger) Warnw(msg string, keysAndValues ...interface{}) {
+	l.SugaredLogger.Warnw(msg, keysAndValues...)
+}
+
+// Errorw логирует сообщение с уровнем Error и loosely-typed key/value парами
+func (l *SugaredLogger) Errorw(msg string, keysAndValues ...interface{}) {
+	l.SugaredLogger.Errorw(msg, keysAndValues...)
+}